@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/alloy/internal/component/otelcol/connector"
 	"github.com/grafana/alloy/internal/featuregate"
 	"github.com/grafana/alloy/syntax"
+	"github.com/mitchellh/mapstructure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
 	otelcomponent "go.opentelemetry.io/collector/component"
 	otelextension "go.opentelemetry.io/collector/extension"
@@ -41,23 +42,55 @@ type Arguments struct {
 	Dimensions        []Dimension `alloy:"dimension,block,optional"`
 	ExcludeDimensions []string    `alloy:"exclude_dimensions,attr,optional"`
 
+	// CallsDimensions, HistogramDimensions, and EventsDimensions add dimensions scoped to a single metric
+	// family, on top of the global Dimensions list above. This lets the calls counter be tagged with
+	// high-cardinality attributes like http.route while keeping the latency histogram low-cardinality, avoiding
+	// a combinatorial explosion of histogram buckets times label values.
+	CallsDimensions     DimensionsConfig `alloy:"calls_dimensions,block,optional"`
+	HistogramDimensions DimensionsConfig `alloy:"histogram_dimensions,block,optional"`
+	EventsDimensions    DimensionsConfig `alloy:"events_dimensions,block,optional"`
+
 	// DimensionsCacheSize defines the size of cache for storing Dimensions, which helps to avoid cache memory growing
 	// indefinitely over the lifetime of the collector.
 	DimensionsCacheSize int `alloy:"dimensions_cache_size,attr,optional"`
 
+	// ResourceMetricsCacheSize defines the size of the cache for storing per-resource metrics. This is mostly
+	// relevant to the "cumulative" AggregationTemporality, since in that case metrics are aggregated in memory
+	// forever, while in the "delta" case metrics are aggregated in memory only for MetricsFlushInterval.
+	ResourceMetricsCacheSize int `alloy:"resource_metrics_cache_size,attr,optional"`
+
+	// ResourceMetricsKeyAttributes filters the resource attributes used to compute the resource metrics key,
+	// so that metrics are not accidentally separated into different buckets because of additional resource
+	// attributes that happen to differ across otherwise identical resources.
+	ResourceMetricsKeyAttributes []string `alloy:"resource_metrics_key_attributes,attr,optional"`
+
 	AggregationTemporality string `alloy:"aggregation_temporality,attr,optional"`
 
 	Histogram HistogramConfig `alloy:"histogram,block"`
 
+	// MetricsUnit selects the unit used for the latency histogram's buckets and sum: "ms" (default) or "s".
+	MetricsUnit string `alloy:"metrics_unit,attr,optional"`
+
 	// MetricsEmitInterval is the time period between when metrics are flushed or emitted to the downstream components.
 	MetricsFlushInterval time.Duration `alloy:"metrics_flush_interval,attr,optional"`
 
+	// MetricsExpiration is the time period after which a series that has stopped receiving spans is dropped.
+	// It is only relevant when AggregationTemporality is "DELTA". A value of 0 means series never expire.
+	MetricsExpiration time.Duration `alloy:"metrics_expiration,attr,optional"`
+
+	// TimestampCacheSize defines the size of cache for storing the last seen timestamp of each series, which is
+	// used to emit uninterrupted start/end timestamps for delta temporality series.
+	TimestampCacheSize int `alloy:"timestamp_cache_size,attr,optional"`
+
 	// Namespace is the namespace of the metrics emitted by the connector.
 	Namespace string `alloy:"namespace,attr,optional"`
 
 	// Exemplars defines the configuration for exemplars.
 	Exemplars ExemplarsConfig `alloy:"exemplars,block,optional"`
 
+	// Events defines the configuration for recording span events as a separate metric.
+	Events EventsConfig `alloy:"events,block,optional"`
+
 	// Output configures where to send processed data. Required.
 	Output *otelcol.ConsumerArguments `alloy:"output,block"`
 }
@@ -73,11 +106,19 @@ const (
 	AggregationTemporalityDelta      = "DELTA"
 )
 
+const (
+	MetricsUnitMilliseconds = "ms"
+	MetricsUnitSeconds      = "s"
+)
+
 // DefaultArguments holds default settings for Arguments.
 var DefaultArguments = Arguments{
-	DimensionsCacheSize:    1000,
-	AggregationTemporality: AggregationTemporalityCumulative,
-	MetricsFlushInterval:   15 * time.Second,
+	DimensionsCacheSize:      1000,
+	ResourceMetricsCacheSize: 1000,
+	AggregationTemporality:   AggregationTemporalityCumulative,
+	MetricsFlushInterval:     15 * time.Second,
+	TimestampCacheSize:       1000,
+	MetricsUnit:              MetricsUnitMilliseconds,
 }
 
 // SetToDefault implements syntax.Defaulter.
@@ -93,10 +134,26 @@ func (args *Arguments) Validate() error {
 			args.DimensionsCacheSize)
 	}
 
+	if args.ResourceMetricsCacheSize <= 0 {
+		return fmt.Errorf(
+			"invalid resource metrics cache size: %v, the maximum number of the items in the cache should be positive",
+			args.ResourceMetricsCacheSize)
+	}
+
 	if args.MetricsFlushInterval <= 0 {
 		return fmt.Errorf("metrics_flush_interval must be greater than 0")
 	}
 
+	if args.MetricsExpiration < 0 {
+		return fmt.Errorf("metrics_expiration must be greater than or equal to 0")
+	}
+
+	if args.TimestampCacheSize <= 0 {
+		return fmt.Errorf(
+			"invalid timestamp cache size: %v, the maximum number of the items in the cache should be positive",
+			args.TimestampCacheSize)
+	}
+
 	switch args.AggregationTemporality {
 	case AggregationTemporalityCumulative, AggregationTemporalityDelta:
 		// Valid
@@ -104,6 +161,19 @@ func (args *Arguments) Validate() error {
 		return fmt.Errorf("invalid aggregation_temporality: %v", args.AggregationTemporality)
 	}
 
+	// Dimensions for the events metric can come from the events block itself, the global Dimensions list, or
+	// the events_dimensions block, so all three must be checked before rejecting the config.
+	if args.Events.Enabled && len(args.Events.Dimensions) == 0 && len(args.Dimensions) == 0 && len(args.EventsDimensions.Dimensions) == 0 {
+		return fmt.Errorf("events.enabled requires at least one dimension to be set, via the events block, the global dimension blocks, or events_dimensions")
+	}
+
+	switch args.MetricsUnit {
+	case MetricsUnitMilliseconds, MetricsUnitSeconds:
+		// Valid
+	default:
+		return fmt.Errorf("invalid metrics_unit: %v, must be %q or %q", args.MetricsUnit, MetricsUnitMilliseconds, MetricsUnitSeconds)
+	}
+
 	return nil
 }
 
@@ -118,6 +188,34 @@ func convertAggregationTemporality(temporality string) (string, error) {
 	}
 }
 
+// The upstream metrics.Unit enum is backed by these integer values (milliseconds is the zero value, matching
+// the connector's own default).
+const (
+	histogramUnitMilliseconds = 0
+	histogramUnitSeconds      = 1
+)
+
+// applyMetricsUnit sets hist.Unit from the Alloy-level metrics_unit attribute. The upstream Unit type is defined
+// in an internal OTel package and can't be referenced directly from here, so it's populated the same way the
+// upstream connector's own config loader would: by decoding a map into the struct via mapstructure. mapstructure
+// can't coerce a string directly into the Unit enum, so the string is first mapped to the enum's underlying int.
+func applyMetricsUnit(hist *spanmetricsconnector.HistogramConfig, unit string) error {
+	var value int
+	switch unit {
+	case MetricsUnitMilliseconds:
+		value = histogramUnitMilliseconds
+	case MetricsUnitSeconds:
+		value = histogramUnitSeconds
+	default:
+		return fmt.Errorf("invalid metrics_unit: %v", unit)
+	}
+
+	if err := mapstructure.Decode(map[string]any{"unit": value}, hist); err != nil {
+		return fmt.Errorf("failed to apply metrics_unit %q: %w", unit, err)
+	}
+	return nil
+}
+
 func FromOTelAggregationTemporality(temporality string) string {
 	switch temporality {
 	case "AGGREGATION_TEMPORALITY_DELTA":
@@ -147,16 +245,31 @@ func (args Arguments) Convert() (otelcomponent.Config, error) {
 	}
 
 	excludeDimensions := append([]string(nil), args.ExcludeDimensions...)
+	resourceMetricsKeyAttributes := append([]string(nil), args.ResourceMetricsKeyAttributes...)
+
+	if err := applyMetricsUnit(histogram, args.MetricsUnit); err != nil {
+		return nil, err
+	}
+	histogram.Dimensions = convertDimensions(args.HistogramDimensions)
+
+	events := args.Events.Convert()
+	events.Dimensions = append(events.Dimensions, convertDimensions(args.EventsDimensions)...)
 
 	return &spanmetricsconnector.Config{
-		Dimensions:             dimensions,
-		ExcludeDimensions:      excludeDimensions,
-		DimensionsCacheSize:    args.DimensionsCacheSize,
-		AggregationTemporality: aggregationTemporality,
-		Histogram:              *histogram,
-		MetricsFlushInterval:   args.MetricsFlushInterval,
-		Namespace:              args.Namespace,
-		Exemplars:              *args.Exemplars.Convert(),
+		Dimensions:                   dimensions,
+		CallsDimensions:              convertDimensions(args.CallsDimensions),
+		ExcludeDimensions:            excludeDimensions,
+		DimensionsCacheSize:          args.DimensionsCacheSize,
+		ResourceMetricsCacheSize:     args.ResourceMetricsCacheSize,
+		ResourceMetricsKeyAttributes: resourceMetricsKeyAttributes,
+		AggregationTemporality:       aggregationTemporality,
+		Histogram:                    *histogram,
+		MetricsFlushInterval:         args.MetricsFlushInterval,
+		MetricsExpiration:            args.MetricsExpiration,
+		TimestampCacheSize:           &args.TimestampCacheSize,
+		Namespace:                    args.Namespace,
+		Exemplars:                    *args.Exemplars.Convert(),
+		Events:                       events,
 	}, nil
 }
 
@@ -178,4 +291,58 @@ func (args Arguments) NextConsumers() *otelcol.ConsumerArguments {
 // ConnectorType() int implements connector.Arguments.
 func (Arguments) ConnectorType() int {
 	return connector.ConnectorTracesToMetrics
-}
\ No newline at end of file
+}
+
+// ExemplarsConfig defines the configuration for exemplars. This is the only ExemplarsConfig declaration in this
+// component; it is not defined elsewhere in this package.
+type ExemplarsConfig struct {
+	Enabled bool `alloy:"enabled,attr,optional"`
+
+	// MaxPerDataPoint caps the number of exemplars attached to a single data point. A value of 0 means no cap.
+	MaxPerDataPoint int `alloy:"max_per_data_point,attr,optional"`
+}
+
+// Convert converts ExemplarsConfig to the upstream connector's exemplars config.
+func (e ExemplarsConfig) Convert() *spanmetricsconnector.ExemplarsConfig {
+	return &spanmetricsconnector.ExemplarsConfig{
+		Enabled:         e.Enabled,
+		MaxPerDataPoint: e.MaxPerDataPoint,
+	}
+}
+
+// DimensionsConfig is a list of additional Dimensions scoped to a single metric family.
+type DimensionsConfig struct {
+	Dimensions []Dimension `alloy:"dimension,block,optional"`
+}
+
+// convertDimensions converts a metric-family-scoped DimensionsConfig. The upstream connector already unions the
+// top-level Dimensions list into every metric family, so this must not also prepend the global list, or each
+// global dimension would be applied twice per family.
+func convertDimensions(scoped DimensionsConfig) []spanmetricsconnector.Dimension {
+	converted := make([]spanmetricsconnector.Dimension, 0, len(scoped.Dimensions))
+	for _, d := range scoped.Dimensions {
+		converted = append(converted, d.Convert())
+	}
+	return converted
+}
+
+// EventsConfig defines the configuration for recording span events as a "traces.spanmetrics.events" counter,
+// partitioned by the span event name plus the configured Dimensions. This is the only EventsConfig declaration
+// in this component; it is not defined elsewhere in this package.
+type EventsConfig struct {
+	Enabled    bool        `alloy:"enabled,attr,optional"`
+	Dimensions []Dimension `alloy:"dimension,block,optional"`
+}
+
+// Convert converts EventsConfig to the upstream connector's events config.
+func (e EventsConfig) Convert() spanmetricsconnector.EventsConfig {
+	dimensions := make([]spanmetricsconnector.Dimension, 0, len(e.Dimensions))
+	for _, d := range e.Dimensions {
+		dimensions = append(dimensions, d.Convert())
+	}
+
+	return spanmetricsconnector.EventsConfig{
+		Enabled:    e.Enabled,
+		Dimensions: dimensions,
+	}
+}
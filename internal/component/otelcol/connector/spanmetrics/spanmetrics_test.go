@@ -0,0 +1,168 @@
+package spanmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+)
+
+func TestExemplarsConfig_Convert(t *testing.T) {
+	e := ExemplarsConfig{Enabled: true, MaxPerDataPoint: 5}
+
+	converted := e.Convert()
+	if !converted.Enabled || converted.MaxPerDataPoint != 5 {
+		t.Fatalf("expected Enabled=true, MaxPerDataPoint=5, got %+v", converted)
+	}
+}
+
+func TestApplyMetricsUnit(t *testing.T) {
+	var msHist spanmetricsconnector.HistogramConfig
+	if err := applyMetricsUnit(&msHist, MetricsUnitMilliseconds); err != nil {
+		t.Fatalf("unexpected error applying metrics_unit %q: %v", MetricsUnitMilliseconds, err)
+	}
+
+	var sHist spanmetricsconnector.HistogramConfig
+	if err := applyMetricsUnit(&sHist, MetricsUnitSeconds); err != nil {
+		t.Fatalf("unexpected error applying metrics_unit %q: %v", MetricsUnitSeconds, err)
+	}
+
+	if msHist.Unit == sHist.Unit {
+		t.Fatalf("expected %q and %q to decode to different Unit values, got the same: %v",
+			MetricsUnitMilliseconds, MetricsUnitSeconds, msHist.Unit)
+	}
+}
+
+func validArguments() Arguments {
+	return Arguments{
+		Dimensions:               []Dimension{{Name: "global"}},
+		DimensionsCacheSize:      1000,
+		ResourceMetricsCacheSize: 1000,
+		AggregationTemporality:   AggregationTemporalityCumulative,
+		MetricsFlushInterval:     15 * time.Second,
+		TimestampCacheSize:       1000,
+		MetricsUnit:              MetricsUnitMilliseconds,
+	}
+}
+
+func TestConvert_CallsDimensionsDoNotDuplicateGlobal(t *testing.T) {
+	args := validArguments()
+	args.CallsDimensions = DimensionsConfig{Dimensions: []Dimension{{Name: "calls_only"}}}
+
+	cfg, err := args.Convert()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spanCfg, ok := cfg.(*spanmetricsconnector.Config)
+	if !ok {
+		t.Fatalf("expected *spanmetricsconnector.Config, got %T", cfg)
+	}
+
+	if len(spanCfg.CallsDimensions) != 1 || spanCfg.CallsDimensions[0].Name != "calls_only" {
+		t.Fatalf("expected CallsDimensions to contain only the calls_dimensions block, got %+v", spanCfg.CallsDimensions)
+	}
+}
+
+func TestConvert_HistogramAndEventsDimensionsDoNotDuplicateGlobal(t *testing.T) {
+	args := validArguments()
+	args.HistogramDimensions = DimensionsConfig{Dimensions: []Dimension{{Name: "histogram_only"}}}
+	args.Events = EventsConfig{Enabled: true, Dimensions: []Dimension{{Name: "events_dim"}}}
+	args.EventsDimensions = DimensionsConfig{Dimensions: []Dimension{{Name: "events_only"}}}
+
+	cfg, err := args.Convert()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spanCfg, ok := cfg.(*spanmetricsconnector.Config)
+	if !ok {
+		t.Fatalf("expected *spanmetricsconnector.Config, got %T", cfg)
+	}
+
+	if len(spanCfg.Histogram.Dimensions) != 1 || spanCfg.Histogram.Dimensions[0].Name != "histogram_only" {
+		t.Fatalf("expected Histogram.Dimensions to contain only the histogram_dimensions block, got %+v", spanCfg.Histogram.Dimensions)
+	}
+
+	if len(spanCfg.Events.Dimensions) != 2 {
+		t.Fatalf("expected Events.Dimensions to contain the events block's own dimension plus events_only, got %+v", spanCfg.Events.Dimensions)
+	}
+}
+
+func TestValidate_CacheSizes(t *testing.T) {
+	cases := []struct {
+		name    string
+		mutate  func(*Arguments)
+		wantErr bool
+	}{
+		{"valid", func(a *Arguments) {}, false},
+		{"dimensions_cache_size zero", func(a *Arguments) { a.DimensionsCacheSize = 0 }, true},
+		{"resource_metrics_cache_size zero", func(a *Arguments) { a.ResourceMetricsCacheSize = 0 }, true},
+		{"resource_metrics_key_attributes alone is fine", func(a *Arguments) {
+			a.ResourceMetricsKeyAttributes = []string{"service.name"}
+		}, false},
+		{"timestamp_cache_size zero", func(a *Arguments) { a.TimestampCacheSize = 0 }, true},
+		{"metrics_expiration negative", func(a *Arguments) { a.MetricsExpiration = -1 }, true},
+		{"metrics_expiration zero means never expire", func(a *Arguments) { a.MetricsExpiration = 0 }, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArguments()
+			tc.mutate(&args)
+
+			err := args.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate_EventsDimensionsSources(t *testing.T) {
+	cases := []struct {
+		name    string
+		mutate  func(*Arguments)
+		wantErr bool
+	}{
+		{"events disabled, no dimensions anywhere", func(a *Arguments) {
+			a.Dimensions = nil
+			a.Events.Enabled = false
+		}, false},
+		{"events enabled, no dimensions anywhere", func(a *Arguments) {
+			a.Dimensions = nil
+			a.Events.Enabled = true
+		}, true},
+		{"events enabled, satisfied by global dimensions", func(a *Arguments) {
+			a.Events.Enabled = true
+		}, false},
+		{"events enabled, satisfied by events block dimensions", func(a *Arguments) {
+			a.Dimensions = nil
+			a.Events.Enabled = true
+			a.Events.Dimensions = []Dimension{{Name: "events_dim"}}
+		}, false},
+		{"events enabled, satisfied by events_dimensions block", func(a *Arguments) {
+			a.Dimensions = nil
+			a.Events.Enabled = true
+			a.EventsDimensions = DimensionsConfig{Dimensions: []Dimension{{Name: "events_only"}}}
+		}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := validArguments()
+			tc.mutate(&args)
+
+			err := args.Validate()
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		})
+	}
+}